@@ -1,47 +1,38 @@
-package main
-
-import (
-	"encoding/json"
-	"fmt"
-	"log"
-
-	_ "embed"
-)
-
-//go:embed subdomainFinger.json
-var subdomainFinger string
-
-type FingerprintRecord struct {
-	CICDPass      bool
-	Cname         []string
-	Discussion    string
-	Documentation string
-	Fingerprint   string
-	HTTPStatus    interface{}
-	NXDomain      bool
-	Service       string
-	Status        string
-	Vulnerable    bool
-}
-
-type PackjsonSubdomain struct {
-	Fingerprint []FingerprintRecord
-}
-
-func main() {
-
-	// 使用零长度切片，让json.Unmarshal自动调整大小
-	items := make([]FingerprintRecord, 0)
-	err := json.Unmarshal([]byte(subdomainFinger), &items)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// 打印解析后的数据
-	for _, item := range items {
-		fmt.Printf("Cname: %v\n", item.Cname)
-		fmt.Printf("Service: %s\n", item.Service)
-		fmt.Printf("Vulnerable: %t\n", item.Vulnerable)
-		fmt.Println("-------------")
-	}
-}
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/youki992/ARL-Web-Alone/pkg/subfinger"
+)
+
+func main() {
+	items, err := subfinger.EmbeddedRecords()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 打印解析后的数据
+	for _, item := range items {
+		fmt.Printf("Cname: %v\n", item.Cname)
+		fmt.Printf("Service: %s\n", item.Service)
+		fmt.Printf("Vulnerable: %t\n", item.Vulnerable)
+		fmt.Println("-------------")
+	}
+
+	// 如果传入了域名参数，实际跑一遍检测引擎
+	domains := os.Args[1:]
+	if len(domains) == 0 {
+		return
+	}
+
+	detector := subfinger.NewDetector(items, 10*time.Second)
+	findings := detector.Scan(context.Background(), domains, 20)
+	if err := subfinger.WriteJSON(os.Stdout, findings); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,123 @@
+// Command subfinger-server exposes subfinger's fingerprint matching as an
+// HTTP API, so ARL's Python side (and other Go tools) can consume it
+// without linking the library directly.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/youki992/ARL-Web-Alone/pkg/subfinger"
+)
+
+type server struct {
+	store    *subfinger.FingerprintStore
+	detector func([]subfinger.FingerprintRecord) *subfinger.Detector
+}
+
+type checkRequest struct {
+	Domain string `json:"domain"`
+}
+
+func (s *server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req checkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Domain == "" {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	records, err := s.store.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	detector := s.detector(records)
+	finding, err := detector.Check(r.Context(), req.Domain)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(finding)
+}
+
+func (s *server) handleFingerprints(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	records, err := s.store.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+func (s *server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.store.RefreshFrom(""); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	channel := flag.String("channel", string(subfinger.ChannelStable), "fingerprint channel (stable)")
+	cachePath := flag.String("cache", "subdomainFinger.cache.json", "path to cache the last good fingerprint list")
+	watchInterval := flag.Duration("watch-interval", 0, "refresh the fingerprint list on this interval (0 disables)")
+	flag.Parse()
+
+	store := subfinger.NewFingerprintStore(subfinger.Channel(*channel), *cachePath)
+	if _, err := store.Load(); err != nil {
+		log.Fatalf("load fingerprints: %v", err)
+	}
+
+	if *watchInterval > 0 {
+		stop := make(chan struct{})
+		go store.Watch(*watchInterval, stop)
+		defer close(stop)
+	}
+
+	srv := &server{
+		store: store,
+		detector: func(records []subfinger.FingerprintRecord) *subfinger.Detector {
+			return subfinger.NewDetector(records, 10*time.Second)
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/check", srv.handleCheck)
+	mux.HandleFunc("/fingerprints", srv.handleFingerprints)
+	mux.HandleFunc("/reload", srv.handleReload)
+
+	log.Printf("subfinger-server listening on %s (channel=%s)", *addr, *channel)
+	httpServer := &http.Server{
+		Addr:         *addr,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,267 @@
+package subfinger
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Finding is the result of checking a single domain against the fingerprint
+// ruleset.
+type Finding struct {
+	Domain     string `json:"domain"`
+	Service    string `json:"service"`
+	Vulnerable bool   `json:"vulnerable"`
+	Status     string `json:"status"`
+	Evidence   string `json:"evidence"`
+}
+
+// Detector checks domains for subdomain takeover against a set of
+// FingerprintRecords.
+type Detector struct {
+	records  []FingerprintRecord
+	resolver *net.Resolver
+	client   *http.Client
+	timeout  time.Duration
+}
+
+// NewDetector builds a Detector bound to records, using timeout as the
+// per-request budget for DNS and HTTP lookups.
+func NewDetector(records []FingerprintRecord, timeout time.Duration) *Detector {
+	return &Detector{
+		records:  records,
+		resolver: net.DefaultResolver,
+		client:   &http.Client{Timeout: timeout},
+		timeout:  timeout,
+	}
+}
+
+// Check resolves domain's CNAME chain and, for any matching fingerprint,
+// confirms takeover via NXDOMAIN or an HTTP body/status match.
+func (d *Detector) Check(ctx context.Context, domain string) (*Finding, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	cname, nxdomain, err := d.resolveCNAME(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range d.records {
+		if !matchesCname(rec.Cname, cname) {
+			continue
+		}
+
+		if rec.NXDomain {
+			if nxdomain {
+				return &Finding{
+					Domain:     domain,
+					Service:    rec.Service,
+					Vulnerable: true,
+					Status:     "nxdomain",
+					Evidence:   fmt.Sprintf("cname %s resolves to NXDOMAIN", cname),
+				}, nil
+			}
+			continue
+		}
+
+		finding, err := d.checkHTTP(ctx, domain, rec)
+		if err != nil {
+			return nil, err
+		}
+		if finding != nil {
+			return finding, nil
+		}
+	}
+
+	return &Finding{Domain: domain, Vulnerable: false, Status: "not vulnerable"}, nil
+}
+
+func (d *Detector) checkHTTP(ctx context.Context, domain string, rec FingerprintRecord) (*Finding, error) {
+	for _, scheme := range []string{"https", "http"} {
+		url := scheme + "://" + domain
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			continue
+		}
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			continue
+		}
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			continue
+		}
+
+		if !matchesStatus(rec.HTTPStatus, resp.StatusCode) {
+			continue
+		}
+		if rec.Fingerprint != "" && !matchesFingerprint(rec.Fingerprint, string(body)) {
+			continue
+		}
+
+		return &Finding{
+			Domain:     domain,
+			Service:    rec.Service,
+			Vulnerable: true,
+			Status:     strconv.Itoa(resp.StatusCode),
+			Evidence:   fmt.Sprintf("%s matched fingerprint %q", url, rec.Fingerprint),
+		}, nil
+	}
+	return nil, nil
+}
+
+// matchesFingerprint treats fingerprint as a substring unless it compiles as
+// a regexp, in which case a regex match is used instead.
+func matchesFingerprint(fingerprint, body string) bool {
+	if re, err := regexp.Compile(fingerprint); err == nil {
+		return re.MatchString(body)
+	}
+	return strings.Contains(body, fingerprint)
+}
+
+// resolveCNAME returns domain's CNAME target and whether that target is
+// dangling (NXDOMAIN). These are resolved independently: LookupCNAME
+// succeeds and returns the target as soon as domain has a CNAME record,
+// even if the target itself doesn't resolve, so nxdomain can't be read off
+// of that call's error — it has to come from resolving the target.
+func (d *Detector) resolveCNAME(ctx context.Context, domain string) (cname string, nxdomain bool, err error) {
+	cname, err = d.resolver.LookupCNAME(ctx, domain)
+	if err != nil {
+		if isNXDomain(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("resolve %s: %w", domain, err)
+	}
+	cname = strings.TrimSuffix(cname, ".")
+
+	if _, err := d.resolver.LookupHost(ctx, cname); err != nil {
+		if isNXDomain(err) {
+			return cname, true, nil
+		}
+		return cname, false, fmt.Errorf("resolve %s: %w", cname, err)
+	}
+	return cname, false, nil
+}
+
+func isNXDomain(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func matchesCname(suffixes []string, cname string) bool {
+	if cname == "" {
+		return false
+	}
+	for _, suffix := range suffixes {
+		suffix = strings.TrimSuffix(suffix, ".")
+		if cname == suffix || strings.HasSuffix(cname, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesStatus handles FingerprintRecord.HTTPStatus, which the upstream
+// schema encodes as int, []interface{} of ints, or nil (meaning "any status").
+func matchesStatus(want interface{}, got int) bool {
+	switch v := want.(type) {
+	case nil:
+		return true
+	case float64:
+		return int(v) == got
+	case int:
+		return v == got
+	case []interface{}:
+		for _, item := range v {
+			if f, ok := item.(float64); ok && int(f) == got {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Scan runs Check across hosts using a fixed-size worker pool and returns
+// findings in completion order.
+func (d *Detector) Scan(ctx context.Context, hosts []string, workers int) []*Finding {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	jobs := make(chan string)
+	results := make(chan *Finding)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				finding, err := d.Check(ctx, host)
+				if err != nil {
+					results <- &Finding{Domain: host, Status: "error", Evidence: err.Error()}
+					continue
+				}
+				results <- finding
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, host := range hosts {
+			jobs <- host
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	findings := make([]*Finding, 0, len(hosts))
+	for finding := range results {
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// WriteJSON writes findings to w as a JSON array.
+func WriteJSON(w io.Writer, findings []*Finding) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(findings)
+}
+
+// WriteCSV writes findings to w as CSV with a header row.
+func WriteCSV(w io.Writer, findings []*Finding) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"domain", "service", "vulnerable", "status", "evidence"}); err != nil {
+		return err
+	}
+	for _, f := range findings {
+		if err := cw.Write([]string{
+			f.Domain, f.Service, strconv.FormatBool(f.Vulnerable), f.Status, f.Evidence,
+		}); err != nil {
+			return err
+		}
+	}
+	return cw.Error()
+}
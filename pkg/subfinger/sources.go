@@ -0,0 +1,203 @@
+package subfinger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FingerprintSource normalizes some on-disk fingerprint format into the
+// repo's own FingerprintRecord shape.
+type FingerprintSource interface {
+	// Load reads path and returns the records it contains.
+	Load(path string) ([]FingerprintRecord, error)
+}
+
+// NucleiSource adapts nuclei takeover-detect templates, which express their
+// match rules as an http request block with "words"/"regex" matchers.
+//
+// Nuclei templates carry no CNAME suffix, so records loaded here have an
+// empty Cname and matchesCname will never select them — Detector.Check
+// only fires on CNAME-gated records today. They still merge into the
+// shared FingerprintRecord list for callers that just want the fingerprint
+// data (e.g. service lookup by name), but won't drive takeover detection
+// until the detector supports a CNAME-less HTTP-only match path.
+type NucleiSource struct{}
+
+type nucleiTemplate struct {
+	Info struct {
+		Name string `yaml:"name"`
+	} `yaml:"info"`
+	HTTP []struct {
+		Matchers []struct {
+			Type   string   `yaml:"type"`
+			Words  []string `yaml:"words"`
+			Regex  []string `yaml:"regex"`
+			Status []int    `yaml:"status"`
+		} `yaml:"matchers"`
+	} `yaml:"http"`
+}
+
+func (NucleiSource) Load(path string) ([]FingerprintRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var tpl nucleiTemplate
+	if err := yaml.Unmarshal(data, &tpl); err != nil {
+		return nil, fmt.Errorf("parse nuclei template %s: %w", path, err)
+	}
+
+	records := make([]FingerprintRecord, 0, len(tpl.HTTP))
+	for _, req := range tpl.HTTP {
+		for _, m := range req.Matchers {
+			rec := FingerprintRecord{
+				Service: tpl.Info.Name,
+			}
+			switch {
+			case len(m.Words) > 0:
+				rec.Fingerprint = m.Words[0]
+			case len(m.Regex) > 0:
+				rec.Fingerprint = m.Regex[0]
+			}
+			if len(m.Status) == 1 {
+				rec.HTTPStatus = float64(m.Status[0])
+			} else if len(m.Status) > 1 {
+				statuses := make([]interface{}, len(m.Status))
+				for i, s := range m.Status {
+					statuses[i] = float64(s)
+				}
+				rec.HTTPStatus = statuses
+			}
+			if rec.Service != "" {
+				records = append(records, rec)
+			}
+		}
+	}
+	return records, nil
+}
+
+// SubjackSource adapts subjack's fingerprints.json, which is already close
+// to our own schema (Cname/Fingerprint/Service/NXDomain fields).
+type SubjackSource struct{}
+
+type subjackEntry struct {
+	Service  string   `json:"service"`
+	Cname    []string `json:"cname"`
+	Cicd     bool     `json:"cicd"`
+	Fp       string   `json:"fingerprint"`
+	NXDomain bool     `json:"nxdomain"`
+}
+
+func (SubjackSource) Load(path string) ([]FingerprintRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var entries []subjackEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse subjack fingerprints %s: %w", path, err)
+	}
+
+	records := make([]FingerprintRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, FingerprintRecord{
+			Service:     e.Service,
+			Cname:       e.Cname,
+			CICDPass:    e.Cicd,
+			Fingerprint: e.Fp,
+			NXDomain:    e.NXDomain,
+			Vulnerable:  true,
+		})
+	}
+	return records, nil
+}
+
+// SubzySource adapts subzy's fingerprints.yml, keyed by provider name with a
+// cname/response/status_code per entry.
+type SubzySource struct{}
+
+type subzyEntry struct {
+	Name       string   `yaml:"name"`
+	Cname      []string `yaml:"cname"`
+	Response   string   `yaml:"response"`
+	StatusCode int      `yaml:"status_code"`
+}
+
+func (SubzySource) Load(path string) ([]FingerprintRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var entries []subzyEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse subzy fingerprints %s: %w", path, err)
+	}
+
+	records := make([]FingerprintRecord, 0, len(entries))
+	for _, e := range entries {
+		rec := FingerprintRecord{
+			Service:     e.Name,
+			Cname:       e.Cname,
+			Fingerprint: e.Response,
+			Vulnerable:  true,
+		}
+		if e.StatusCode != 0 {
+			rec.HTTPStatus = float64(e.StatusCode)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// sourceFor picks the adapter matching path's extension and naming
+// convention: "*.yaml"/"*.yml" nuclei templates, "*.json" (including
+// subjack's canonical "fingerprints.json") for subjack, and everything
+// else falls back to subzy's YAML format.
+func sourceFor(path string) FingerprintSource {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.Contains(lower, "subjack"):
+		return SubjackSource{}
+	case strings.Contains(lower, "subzy"):
+		return SubzySource{}
+	case strings.HasSuffix(lower, ".json"):
+		return SubjackSource{}
+	case strings.HasSuffix(lower, ".yaml"), strings.HasSuffix(lower, ".yml"):
+		return NucleiSource{}
+	default:
+		return SubzySource{}
+	}
+}
+
+// LoadSources merges records from every path, normalizing via sourceFor,
+// deduplicating by Service, and letting later paths override earlier ones.
+func LoadSources(paths ...string) ([]FingerprintRecord, error) {
+	byService := make(map[string]FingerprintRecord)
+	order := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		records, err := sourceFor(path).Load(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			if _, seen := byService[rec.Service]; !seen {
+				order = append(order, rec.Service)
+			}
+			byService[rec.Service] = rec
+		}
+	}
+
+	merged := make([]FingerprintRecord, 0, len(order))
+	for _, service := range order {
+		merged = append(merged, byService[service])
+	}
+	return merged, nil
+}
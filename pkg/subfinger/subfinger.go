@@ -0,0 +1,41 @@
+// Package subfinger holds the subdomain-takeover fingerprint data model and
+// detection engine, shared by the app/dicts demo and cmd/subfinger-server.
+package subfinger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	_ "embed"
+)
+
+//go:embed subdomainFinger.json
+var subdomainFinger string
+
+type FingerprintRecord struct {
+	CICDPass      bool        `json:"cicd_pass"`
+	Cname         []string    `json:"cname"`
+	Discussion    string      `json:"discussion"`
+	Documentation string      `json:"documentation"`
+	Fingerprint   string      `json:"fingerprint"`
+	HTTPStatus    interface{} `json:"http_status"`
+	NXDomain      bool        `json:"nxdomain"`
+	Service       string      `json:"service"`
+	Status        string      `json:"status"`
+	Vulnerable    bool        `json:"vulnerable"`
+}
+
+type PackjsonSubdomain struct {
+	Fingerprint []FingerprintRecord `json:"fingerprint"`
+}
+
+// EmbeddedRecords parses the fingerprint list baked into the binary via
+// go:embed. It's the fallback used until FingerprintStore.RefreshFrom has
+// pulled something newer.
+func EmbeddedRecords() ([]FingerprintRecord, error) {
+	items := make([]FingerprintRecord, 0)
+	if err := json.Unmarshal([]byte(subdomainFinger), &items); err != nil {
+		return nil, fmt.Errorf("parse embedded fingerprints: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,180 @@
+package subfinger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Channel is a named upstream feed, similar to how k3d resolves "latest"/"stable"
+// release channels from a channelserver. Only "stable" is wired up today —
+// there's no second upstream worth calling "edge" yet, so we don't pretend
+// one exists by aliasing it to the same URL.
+type Channel string
+
+const (
+	ChannelStable Channel = "stable"
+)
+
+// defaultChannelURLs maps each supported channel to its upstream fingerprints.json,
+// defaulting to the can-i-take-over-xyz project.
+var defaultChannelURLs = map[Channel]string{
+	ChannelStable: "https://raw.githubusercontent.com/EdOverflow/can-i-take-over-xyz/master/fingerprints.json",
+}
+
+// FingerprintStore holds the in-memory fingerprint list and knows how to refresh
+// it from an upstream channel, falling back to a cached copy on disk.
+type FingerprintStore struct {
+	mu       sync.RWMutex
+	records  []FingerprintRecord
+	etag     string
+	modified string
+
+	channel   Channel
+	cachePath string
+	client    *http.Client
+	logger    *log.Logger
+}
+
+// NewFingerprintStore builds a store for the given channel that caches its last
+// good copy at cachePath. The embedded subdomainFinger.json is used as the
+// initial seed until the first successful RefreshFrom. Refresh failures
+// encountered by Watch are reported through logger, defaulting to log.Default.
+func NewFingerprintStore(channel Channel, cachePath string) *FingerprintStore {
+	return &FingerprintStore{
+		channel:   channel,
+		cachePath: cachePath,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		logger:    log.Default(),
+	}
+}
+
+// SetLogger overrides the logger used to report refresh failures from Watch.
+func (s *FingerprintStore) SetLogger(logger *log.Logger) {
+	s.logger = logger
+}
+
+// Load reads the current records, preferring the on-disk cache over the
+// embedded fallback if a cache exists.
+func (s *FingerprintStore) Load() ([]FingerprintRecord, error) {
+	if data, err := os.ReadFile(s.cachePath); err == nil {
+		var cached []FingerprintRecord
+		if err := json.Unmarshal(data, &cached); err == nil && len(cached) > 0 {
+			s.mu.Lock()
+			s.records = cached
+			s.mu.Unlock()
+			return s.snapshot(), nil
+		}
+	}
+
+	items, err := EmbeddedRecords()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.records = items
+	s.mu.Unlock()
+	return s.snapshot(), nil
+}
+
+// RefreshFrom fetches fingerprints.json from url, validates it against
+// FingerprintRecord, and atomically swaps the in-memory records on success.
+// An empty url falls back to the default URL for the store's channel.
+func (s *FingerprintStore) RefreshFrom(url string) error {
+	if url == "" {
+		url = defaultChannelURLs[s.channel]
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	s.mu.RLock()
+	etag, modified := s.etag, s.modified
+	s.mu.RUnlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if modified != "" {
+		req.Header.Set("If-Modified-Since", modified)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	items := make([]FingerprintRecord, 0)
+	if err := json.Unmarshal(body, &items); err != nil {
+		return fmt.Errorf("validate schema: %w", err)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("validate schema: %s returned no records", url)
+	}
+
+	s.mu.Lock()
+	s.records = items
+	s.etag = resp.Header.Get("ETag")
+	s.modified = resp.Header.Get("Last-Modified")
+	s.mu.Unlock()
+
+	return s.writeCache(body)
+}
+
+// Watch polls RefreshFrom on the given interval until ctx-less stop channel
+// closes. Callers typically run it in a goroutine: `go store.Watch(interval, stop)`.
+func (s *FingerprintStore) Watch(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.RefreshFrom(""); err != nil {
+				s.logger.Printf("fingerprint refresh failed: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *FingerprintStore) writeCache(body []byte) error {
+	if s.cachePath == "" {
+		return nil
+	}
+	if dir := filepath.Dir(s.cachePath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create cache dir: %w", err)
+		}
+	}
+	return os.WriteFile(s.cachePath, body, 0o644)
+}
+
+func (s *FingerprintStore) snapshot() []FingerprintRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]FingerprintRecord, len(s.records))
+	copy(out, s.records)
+	return out
+}